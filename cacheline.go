@@ -0,0 +1,9 @@
+package lrlock
+
+import "math/bits"
+
+// cacheLineBits is derived once from the GOARCH-specific cacheLineSize (see
+// cacheline_*.go). cacheLineSize/4 is assumed to be a power of two, since
+// cache lines are themselves a power-of-two number of bytes and a slot is
+// one int32 (4 bytes).
+var cacheLineBits = uint(bits.Len(uint(cacheLineSize/4))) - 1
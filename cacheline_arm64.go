@@ -0,0 +1,4 @@
+package lrlock
+
+// cacheLineSize is the size, in bytes, of a cache line on this GOARCH.
+const cacheLineSize = 64
@@ -0,0 +1,7 @@
+//go:build !amd64 && !arm64 && !ppc64 && !ppc64le
+
+package lrlock
+
+// cacheLineSize is a conservative default for GOARCHes without a more
+// specific entry in another cacheline_*.go file.
+const cacheLineSize = 64
@@ -0,0 +1,6 @@
+//go:build ppc64 || ppc64le
+
+package lrlock
+
+// cacheLineSize is the size, in bytes, of a cache line on this GOARCH.
+const cacheLineSize = 128
@@ -34,3 +34,14 @@ func ExampleLRMutex() {
 	fmt.Println(getGlobal())
 	// Output: foobar
 }
+
+func ExampleLRValue() {
+	// LRValue wraps up the two-copy bookkeeping from the LRMutex example
+	// above into a single value, for callers who don't want to think about
+	// the underlying left-right protocol.
+	var global lrlock.LRValue[string]
+
+	global.Store(func(s *string) { *s = "foobar" })
+	fmt.Println(global.Load())
+	// Output: foobar
+}
@@ -6,6 +6,7 @@
 package lrlock
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 )
@@ -71,6 +72,65 @@ func (l *LRMutex) Lock() LockToken {
 	}
 }
 
+// LockContext is a context-aware version of Lock: it starts a round of the
+// write protocol, but returns early with ctx.Err() if ctx is done before the
+// lock is acquired. On failure, the returned token is the zero value and
+// must not be used.
+func (l *LRMutex) LockContext(ctx context.Context) (LockToken, error) {
+	l.once.Do(l.init)
+
+	if err := ctx.Err(); err != nil {
+		return LockToken{}, err
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		l.wmu.Lock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-ctx.Done():
+		// Someone still has to claim the lock once it's eventually
+		// acquired, or it would stay held forever.
+		go func() {
+			<-acquired
+			l.wmu.Unlock()
+		}()
+		return LockToken{}, ctx.Err()
+	}
+
+	leftRight := atomic.LoadInt32(&l.state) >> 1
+
+	return LockToken{
+		nonzero:  true,
+		startIdx: byte((leftRight + 1) % 2),
+		incrs:    0,
+		l:        l,
+	}, nil
+}
+
+// TryLock attempts to start a round of the write protocol without blocking.
+// It reports whether the attempt succeeded; on failure, the returned token
+// is the zero value and must not be used.
+func (l *LRMutex) TryLock() (LockToken, bool) {
+	l.once.Do(l.init)
+
+	if !l.wmu.TryLock() {
+		return LockToken{}, false
+	}
+
+	leftRight := atomic.LoadInt32(&l.state) >> 1
+
+	return LockToken{
+		nonzero:  true,
+		startIdx: byte((leftRight + 1) % 2),
+		incrs:    0,
+		l:        l,
+	}, true
+}
+
 // Next advances the write protocol to the next step.
 func (w *LockToken) Next() bool {
 	if !w.nonzero {
@@ -118,9 +178,26 @@ func (w *LockToken) Next() bool {
 	return w.incrs < 3
 }
 
-// TODO: there should be a way to bail on the write protocol more quickly, if
-// you see on the first index that no write is required. In that case, you
-// shouldn't need to wait for two rounds of reader-draining.
+// Abort ends the write protocol early, for callers that inspect the first
+// copy (via Idx()) and discover that no write is actually needed. It must
+// only be called after the first Next() call has returned true, and before
+// any further call to Next(); calling it after mutating the first
+// copy would leave the two copies diverged, since Abort() skips toggling
+// state and thus never publishes a write to the second copy.
+//
+// This lets "write if changed" callers avoid paying for two full rounds of
+// reader-draining when it turns out there's nothing to do.
+func (w *LockToken) Abort() {
+	if !w.nonzero {
+		panic("Use of a zero LockToken is invalid.")
+	}
+	if w.incrs != 1 {
+		panic("Abort() may only be called after the first Next() call has returned true, and before any further call to Next().")
+	}
+
+	w.incrs = 3
+	w.l.wmu.Unlock()
+}
 
 func (w *LockToken) waitForNonVersionIndex(state int32) {
 	rc := &w.l.refCounts[(state&1+1)%2]
@@ -130,6 +207,36 @@ func (w *LockToken) waitForNonVersionIndex(state int32) {
 	*rc = allocateRefCount(*rc)
 }
 
+// NextContext is a context-aware version of Next: before starting a round's
+// work, it checks ctx and returns ctx.Err() instead if it's already done.
+//
+// Once a round has started, though, it always runs to completion, ignoring
+// ctx: the round's two steps toggle leftRight and versionIndex in turn, each
+// publishing the change to readers before draining whichever refCount holds
+// readers still looking at the copy that change makes inactive. Bailing out
+// partway through would either leave a reader stranded on a copy the next
+// round's writer is about to overwrite, or revert a toggle readers may
+// already be observing — both are real data races or visible
+// inconsistencies, not things ctx can safely unwind. So cancellation can
+// only ever save you from starting a round, never from waiting out one
+// already in flight.
+func (w *LockToken) NextContext(ctx context.Context) (bool, error) {
+	if !w.nonzero {
+		panic("Use of a zero LockToken is invalid.")
+	}
+	if w.incrs >= 3 {
+		panic("Cannot call Next() again after it has returned false.")
+	}
+
+	if err := ctx.Err(); err != nil {
+		w.incrs = 3
+		w.l.wmu.Unlock()
+		return false, err
+	}
+
+	return w.Next(), nil
+}
+
 // Idx returns the index that the writer should be writing into at this time.
 func (w *LockToken) Idx() int {
 	if !w.nonzero {
@@ -174,6 +281,12 @@ func (l *LRMutex) RLock() RLockToken {
 	}
 }
 
+// TryRLock starts the read protocol. Readers never block in this algorithm,
+// so this always succeeds; it exists for API symmetry with TryLock.
+func (l *LRMutex) TryRLock() (RLockToken, bool) {
+	return l.RLock(), true
+}
+
 // Idx returns the index that the reader should be reading from.
 func (r RLockToken) Idx() int {
 	if r.rc == nil {
@@ -0,0 +1,154 @@
+package lrlock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTryLock(t *testing.T) {
+	var l LRMutex
+
+	w, ok := l.TryLock()
+	if !ok {
+		t.Fatal("TryLock() on an unlocked LRMutex failed")
+	}
+
+	if _, ok := l.TryLock(); ok {
+		t.Fatal("TryLock() on an already-locked LRMutex succeeded")
+	}
+
+	for w.Next() {
+	}
+
+	if _, ok := l.TryLock(); !ok {
+		t.Fatal("TryLock() failed after the prior writer finished")
+	}
+}
+
+// TestRLockAllowsBlockingInCriticalSection guards against acquire/release
+// re-pinning the goroutine for the whole read critical section: parking
+// (e.g. on a channel receive) while a P is pinned is a fatal,
+// unrecoverable runtime error, and real read-side critical sections do
+// real, potentially-blocking work.
+func TestRLockAllowsBlockingInCriticalSection(t *testing.T) {
+	var l LRMutex
+	r := l.RLock()
+
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+
+	r.RUnlock()
+}
+
+func TestTryRLock(t *testing.T) {
+	var l LRMutex
+
+	r, ok := l.TryRLock()
+	if !ok {
+		t.Fatal("TryRLock() unexpectedly failed")
+	}
+	r.RUnlock()
+}
+
+func TestLockTokenAbort(t *testing.T) {
+	var l LRMutex
+
+	w := l.Lock()
+	if !w.Next() {
+		t.Fatal("Next() returned false on the first call")
+	}
+	w.Abort()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Next() did not panic after Abort()")
+			}
+		}()
+		w.Next()
+	}()
+
+	// Abort() must not have left wmu held.
+	if _, ok := l.TryLock(); !ok {
+		t.Fatal("TryLock() failed after Abort()")
+	}
+}
+
+func TestLockContextCanceled(t *testing.T) {
+	var l LRMutex
+
+	w := l.Lock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := l.LockContext(ctx); err == nil {
+		t.Fatal("LockContext() with an already-canceled context unexpectedly succeeded")
+	}
+
+	for w.Next() {
+	}
+
+	if _, ok := l.TryLock(); !ok {
+		t.Fatal("TryLock() failed after LockContext() gave up and the original writer finished")
+	}
+}
+
+func TestNextContextAlreadyCanceled(t *testing.T) {
+	var l LRMutex
+
+	w := l.Lock()
+	if !w.Next() {
+		t.Fatal("Next() returned false on the first call")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if ok, err := w.NextContext(ctx); ok || err == nil {
+		t.Fatalf("NextContext() with a canceled context = (%v, %v), want (false, non-nil)", ok, err)
+	}
+
+	if _, ok := l.TryLock(); !ok {
+		t.Fatal("TryLock() failed after NextContext() gave up")
+	}
+}
+
+// TestNextContextRunsRoundToCompletion verifies that once a round has
+// started, NextContext ignores ctx and waits out the reader-drain rather
+// than abandoning it, since either toggle it performs is visible to readers
+// and can't be safely unwound. See the NextContext doc comment.
+func TestNextContextRunsRoundToCompletion(t *testing.T) {
+	var l LRMutex
+
+	w := l.Lock()
+	if !w.Next() {
+		t.Fatal("Next() returned false on the first call")
+	}
+
+	// Held before the round starts, so NextContext must wait for it.
+	r := l.RLock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.NextContext(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("NextContext() returned before the held reader was released")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	r.RUnlock()
+	<-done
+
+	for w.Next() {
+	}
+}
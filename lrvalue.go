@@ -0,0 +1,68 @@
+package lrlock
+
+import "reflect"
+
+// LRValue is a generic, atomic.Value-shaped wrapper around the two-copy
+// pattern that using an LRMutex directly requires callers to implement by
+// hand. It holds both copies of T as well as the LRMutex mediating access to
+// them, so callers get a wait-free Load without having to think about the
+// underlying left-right protocol at all.
+//
+// The zero value of LRValue[T] is valid and ready to use, with both copies
+// holding T's zero value.
+type LRValue[T any] struct {
+	mu   LRMutex
+	data [2]T
+}
+
+// Load returns a copy of the current value. It's wait-free: it never blocks
+// on a concurrent Store or CompareAndSwap.
+func (v *LRValue[T]) Load() T {
+	r := v.mu.RLock()
+	t := v.data[r.Idx()]
+	r.RUnlock()
+	return t
+}
+
+// LoadFn calls fn with a pointer to the current value, letting readers that
+// don't want to pay for a copy of T avoid one. fn must not retain the
+// pointer, or mutate through it, beyond the call: the pointee is shared with
+// concurrent readers, and may be overwritten by the next Store once this
+// call to LoadFn returns.
+func (v *LRValue[T]) LoadFn(fn func(*T)) {
+	r := v.mu.RLock()
+	fn(&v.data[r.Idx()])
+	r.RUnlock()
+}
+
+// Store sets the value, by calling update once per underlying copy, each
+// time passing a pointer to that copy so update can mutate it in place. It
+// follows the standard Lock/Next/Idx write protocol, and so blocks until any
+// readers that are still looking at the previous value have drained.
+func (v *LRValue[T]) Store(update func(*T)) {
+	for w := v.mu.Lock(); w.Next(); {
+		update(&v.data[w.Idx()])
+	}
+}
+
+// CompareAndSwap stores new into v, if the current value is deeply equal
+// (per reflect.DeepEqual) to old, and reports whether the swap happened.
+// Unlike Store, a CompareAndSwap that finds the value already changed skips
+// reader-draining entirely, via LockToken.Abort.
+func (v *LRValue[T]) CompareAndSwap(old, new T) bool {
+	w := v.mu.Lock()
+	if !w.Next() {
+		panic("lrlock: internal state error: Next() returned false on its first call.")
+	}
+
+	if !reflect.DeepEqual(v.data[w.Idx()], old) {
+		w.Abort()
+		return false
+	}
+	v.data[w.Idx()] = new
+
+	for w.Next() {
+		v.data[w.Idx()] = new
+	}
+	return true
+}
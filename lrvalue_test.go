@@ -0,0 +1,52 @@
+package lrlock
+
+import "testing"
+
+func TestLRValueLoadStore(t *testing.T) {
+	var v LRValue[int]
+
+	if got := v.Load(); got != 0 {
+		t.Fatalf("Load() on the zero value = %d, want 0", got)
+	}
+
+	v.Store(func(n *int) { *n = 42 })
+
+	if got := v.Load(); got != 42 {
+		t.Fatalf("Load() after Store() = %d, want 42", got)
+	}
+}
+
+func TestLRValueLoadFn(t *testing.T) {
+	var v LRValue[[]int]
+	v.Store(func(s *[]int) { *s = []int{1, 2, 3} })
+
+	sum := 0
+	v.LoadFn(func(s *[]int) {
+		for _, n := range *s {
+			sum += n
+		}
+	})
+
+	if sum != 6 {
+		t.Fatalf("sum over LoadFn() = %d, want 6", sum)
+	}
+}
+
+func TestLRValueCompareAndSwap(t *testing.T) {
+	var v LRValue[string]
+	v.Store(func(s *string) { *s = "foo" })
+
+	if v.CompareAndSwap("bar", "baz") {
+		t.Fatal("CompareAndSwap(\"bar\", ...) succeeded despite the value being \"foo\"")
+	}
+	if got := v.Load(); got != "foo" {
+		t.Fatalf("Load() after a failed CompareAndSwap = %q, want \"foo\"", got)
+	}
+
+	if !v.CompareAndSwap("foo", "baz") {
+		t.Fatal("CompareAndSwap(\"foo\", ...) failed despite the value being \"foo\"")
+	}
+	if got := v.Load(); got != "baz" {
+		t.Fatalf("Load() after a successful CompareAndSwap = %q, want \"baz\"", got)
+	}
+}
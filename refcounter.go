@@ -28,11 +28,19 @@ type refCount struct {
 	waitch chan struct{}
 }
 
+// acquire claims a slot for the calling goroutine's current P and returns
+// its index. The P is only pinned for the duration of this call, just long
+// enough to keep the id and the slot it selects consistent; it's unpinned
+// again before returning, since the caller may hold the resulting reference
+// across an arbitrarily long, potentially-blocking critical section.
 func (r *refCount) acquire() int {
-	idx := r.idxForP(getp() + 1)
+	id, unpin := pinP()
+	idx := r.idxForP(id + 1)
 	if atomic.AddInt32(&r.counters[idx], 1) == math.MaxInt32 {
+		unpin()
 		panic("refCount does not support more than 2 billion acquires.")
 	}
+	unpin()
 	return idx
 }
 
@@ -48,6 +56,8 @@ func (r *refCount) release(idx int) {
 	r.waitch <- struct{}{}
 }
 
+// wait blocks until every reference acquired before this call has been
+// released.
 func (r *refCount) wait() {
 	pendingSlots := int32(0)
 	for i := 1; i <= r.maxPid(); i++ {
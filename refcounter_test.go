@@ -0,0 +1,56 @@
+package lrlock
+
+import (
+	"testing"
+)
+
+// withFakeRuntime forces gomaxprocs() and getp() to deterministic values for
+// the duration of fn, so that tests can pin down exactly which slots get
+// touched.
+func withFakeRuntime(maxprocs, p int, fn func()) {
+	prevMaxprocs, prevGetp := gomaxprocsOverride, getpOverride
+	gomaxprocsOverride = func() int { return maxprocs }
+	getpOverride = func() int { return p }
+	defer func() {
+		gomaxprocsOverride, getpOverride = prevMaxprocs, prevGetp
+	}()
+	fn()
+}
+
+func TestRefCountAcquireUsesDistinctSlotsPerP(t *testing.T) {
+	withFakeRuntime(4, 0, func() {
+		rc := allocateRefCount(nil)
+
+		idx0 := rc.acquire()
+
+		withFakeRuntime(4, 1, func() {
+			idx1 := rc.acquire()
+
+			if idx0 == idx1 {
+				t.Fatalf("acquire() on different Ps returned the same slot: %d", idx0)
+			}
+		})
+	})
+}
+
+func TestRefCountAcquireReleaseWait(t *testing.T) {
+	withFakeRuntime(4, 2, func() {
+		rc := allocateRefCount(nil)
+
+		idx := rc.acquire()
+		done := make(chan struct{})
+		go func() {
+			rc.wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("wait() returned before the acquired reference was released")
+		default:
+		}
+
+		rc.release(idx)
+		<-done
+	})
+}
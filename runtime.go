@@ -1,19 +1,84 @@
 package lrlock
 
-func gomaxprocs() int {
-	// TODO: implement me. Caveat, it should be fast, shouldn't cause cross-core
-	// communication, and can be eventually consistent.
-	return 4
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+	_ "unsafe" // for go:linkname
+)
+
+// runtime_procPin and runtime_procUnpin pin and unpin the calling
+// goroutine's current P, exactly as sync.Pool does (see runtime/proc.go).
+// Pinning prevents the goroutine from being rescheduled onto a different P
+// between reading its id and finishing whatever it does with the slot that
+// id selects.
+//
+//go:linkname runtime_procPin sync.runtime_procPin
+func runtime_procPin() int
+
+//go:linkname runtime_procUnpin sync.runtime_procUnpin
+func runtime_procUnpin()
+
+// getpOverride, when non-nil, replaces pinP's use of the runtime. It exists
+// so that tests can force specific, deterministic P ids; see
+// refcounter_test.go.
+var getpOverride func() int
+
+// pinP pins the calling goroutine to its current P and returns the P's id
+// along with a function that must be called exactly once to unpin it. The
+// pin must be held for as long as the id remains in play, so that the
+// goroutine can't be rescheduled onto a different P (and hence a different
+// slot) in the middle.
+func pinP() (id int, unpin func()) {
+	if getpOverride != nil {
+		return getpOverride(), func() {}
+	}
+	return runtime_procPin(), runtime_procUnpin
 }
 
-func getp() int {
-	// TODO: implement me. Caveat, it should be fast, and shouldn't cause cross-core
-	// communication.
-	return 0
+// gomaxprocsOverride, when non-nil, replaces gomaxprocs's use of the
+// runtime. It exists so that tests can force a specific GOMAXPROCS value;
+// see refcounter_test.go.
+var gomaxprocsOverride func() int
+
+var (
+	gomaxprocsCache int32
+	gomaxprocsStamp int64
+)
+
+// gomaxprocsRefresh bounds how stale gomaxprocsCache is allowed to get.
+// GOMAXPROCS essentially never changes at runtime, so this only needs to be
+// eventually consistent: idxForP already tolerates a stale, too-small value
+// by masking pids into range.
+const gomaxprocsRefresh = int64(time.Second)
+
+// gomaxprocs returns a cached, lazily-refreshed value of
+// runtime.GOMAXPROCS(0). Caveat: it should be fast, shouldn't cause
+// cross-core communication, and can be eventually consistent.
+func gomaxprocs() int {
+	if gomaxprocsOverride != nil {
+		return gomaxprocsOverride()
+	}
+
+	now := time.Now().UnixNano()
+	if last := atomic.LoadInt64(&gomaxprocsStamp); now-last > gomaxprocsRefresh {
+		fresh := int32(runtime.GOMAXPROCS(0))
+		// Store the refreshed value before publishing the new stamp: once a
+		// concurrent caller observes the new stamp and takes the fast path
+		// below, it must also observe this store, or it could read a
+		// not-yet-initialized gomaxprocsCache.
+		atomic.StoreInt32(&gomaxprocsCache, fresh)
+		atomic.CompareAndSwapInt64(&gomaxprocsStamp, last, now)
+		return int(fresh)
+	}
+	return int(atomic.LoadInt32(&gomaxprocsCache))
 }
 
+// slotsPerCacheLineBits returns the number of low bits of a slot index
+// needed to pack cacheLineSize/4 int32 counters into a single cache line.
+// cacheLineSize is supplied per-GOARCH by the cacheline_*.go files, and
+// can't change over the lifetime of the program, so the result is computed
+// once and cached in cacheLineBits.
 func slotsPerCacheLineBits() uint {
-	// TODO: implement me. Caveat, it should be fast, shouldn't cause cross-core
-	// communication, and shouldln't change over the life time of the program.
-	return 4 // Common cache line is 6 bits long, minus 2 bits for the slot size.
+	return cacheLineBits
 }